@@ -0,0 +1,347 @@
+package option
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/result"
+	"github.com/aquasecurity/trivy/pkg/tracker"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// FormatIssueTracker is the ReportOption.Format value that files/updates
+// issues in an external tracker instead of writing a report file.
+const FormatIssueTracker = "issue-tracker"
+
+// Output represents a single report sink: a format fanned out to a specific
+// destination. It is populated from a repeated `--output format=path` flag
+// and lets a single scan run emit several reports at once.
+type Output struct {
+	Format   string
+	Template string
+	Path     string
+	Writer   *os.File
+}
+
+// ReportOption holds the options for reporting scan results
+type ReportOption struct {
+	// these variables are not exported for testability
+	output                string
+	outputs               []string
+	vulnType              string
+	excludeVulnType       string
+	securityChecks        string
+	excludeSecurityChecks string
+	pkgType               string
+	excludePkgType        string
+	severities            string
+
+	Format        string
+	Template      string
+	IgnoreFile    string
+	IgnorePolicy  string
+	IgnoreUnfixed bool
+	ListAllPkgs   bool
+	ExitCode      int
+	// TrackerConfig is the path to the YAML file passed via --tracker-config,
+	// required when Format is FormatIssueTracker.
+	TrackerConfig string
+	// Tracker is the adapter resolved from TrackerConfig during Init.
+	Tracker        tracker.Tracker
+	VulnType       []string
+	SecurityChecks []string
+	// PkgType is the effective package-ecosystem whitelist computed from
+	// --pkg-type; empty means no restriction (all ecosystems allowed).
+	PkgType []string
+	// ExcludePkgType is the raw --exclude-pkg-type list. Unlike VulnType and
+	// SecurityChecks, package ecosystems have no fixed universe to subtract
+	// from, so the blacklist is kept separate and applied in addition to
+	// PkgType rather than folded into a single effective set.
+	ExcludePkgType []string
+	Severities     []dbTypes.Severity
+	Output         *os.File
+	Outputs        []Output
+	IgnoreRules    result.Rules
+}
+
+// Init initializes the ReportOption
+func (c *ReportOption) Init(output *os.File, logger *zap.SugaredLogger) error {
+	if err := c.initTracker(); err != nil {
+		return err
+	}
+
+	if c.Template != "" {
+		if c.Format == "" {
+			logger.Warn("'--template' is ignored because '--format template' is not specified. Use '--template' option with '--format template' option.")
+		} else if c.Format != "template" {
+			logger.Warnf("'--template' is ignored because '--format %s' is specified. Use '--template' option with '--format template' option.", c.Format)
+		}
+	}
+	if c.Format == "template" && c.Template == "" {
+		logger.Warn("'--format template' is ignored because '--template' is not specified. Specify '--template' option when you use '--format template'.")
+	}
+
+	if c.ListAllPkgs && c.Format == "table" {
+		logger.Warn(`"--list-all-pkgs" cannot be used with "--format table". Try "--format json" or other formats.`)
+	}
+	// '--list-all-pkgs' is enabled automatically for formats that need the
+	// full package list to be useful, mirroring cyclonedx/spdx/spdx-json.
+	switch c.Format {
+	case "cyclonedx", "spdx", "spdx-json":
+		if !c.ListAllPkgs {
+			logger.Debug("'cyclonedx', 'spdx', and 'spdx-json' automatically enables '--list-all-pkgs'.")
+			c.ListAllPkgs = true
+		}
+	case "sarif":
+		if !c.ListAllPkgs {
+			logger.Debug("'sarif' automatically enables '--list-all-pkgs'.")
+			c.ListAllPkgs = true
+		}
+	}
+
+	var severities []dbTypes.Severity
+	for _, s := range strings.Split(c.severities, ",") {
+		severity, err := dbTypes.NewSeverity(strings.ToUpper(s))
+		if err != nil {
+			logger.Warnf("unknown severity option: %s", err)
+		}
+		severities = append(severities, severity)
+	}
+	logger.Debugf("Severities: %s", c.severities)
+	c.Severities = severities
+
+	vulnType, err := c.resolveIncludeExclude("vuln-type", c.vulnType, c.excludeVulnType, types.VulnTypes, types.VulnTypes, logger)
+	if err != nil {
+		return err
+	}
+	c.VulnType = vulnType
+	if len(c.VulnType) == 0 {
+		logger.Info("the effective vulnerability type list is empty; no vulnerabilities will be reported")
+	}
+
+	securityChecks, err := c.resolveIncludeExclude("security-checks", c.securityChecks, c.excludeSecurityChecks,
+		[]string{types.SecurityCheckVulnerability}, types.SecurityChecks, logger)
+	if err != nil {
+		return err
+	}
+	c.SecurityChecks = securityChecks
+	if len(c.SecurityChecks) == 0 {
+		logger.Info("the effective security check list is empty; no scanners will run")
+	}
+
+	pkgTypeInclude := splitNonEmpty(c.pkgType)
+	pkgTypeExclude := splitNonEmpty(c.excludePkgType)
+	for _, p := range pkgTypeExclude {
+		if contains(pkgTypeInclude, p) {
+			return fmt.Errorf("--pkg-type and --exclude-pkg-type conflict on %q", p)
+		}
+	}
+	c.PkgType = pkgTypeInclude
+	c.ExcludePkgType = pkgTypeExclude
+
+	if err := c.initOutputs(output); err != nil {
+		return err
+	}
+
+	if err := c.initIgnorePolicy(logger); err != nil {
+		return err
+	}
+
+	// the raw flag values have now been parsed into their structured,
+	// exported counterparts above
+	c.vulnType = ""
+	c.excludeVulnType = ""
+	c.securityChecks = ""
+	c.excludeSecurityChecks = ""
+	c.pkgType = ""
+	c.excludePkgType = ""
+	c.severities = ""
+
+	return nil
+}
+
+// initOutputs resolves where report(s) get written. Most scans still use the
+// legacy single `--output` path; when `--output` is passed more than once,
+// or with `format=path` syntax, it fans out into c.Outputs instead.
+func (c *ReportOption) initOutputs(output *os.File) error {
+	if len(c.outputs) == 0 {
+		return c.initLegacyOutput(output)
+	}
+
+	if len(c.outputs) == 1 && !strings.Contains(c.outputs[0], "=") {
+		c.output = c.outputs[0]
+		return c.initLegacyOutput(output)
+	}
+
+	var stdoutSinks int
+	outputs := make([]Output, 0, len(c.outputs))
+	for _, raw := range c.outputs {
+		format, path, ok := strings.Cut(raw, "=")
+		if !ok {
+			closeOutputs(outputs)
+			return fmt.Errorf("invalid --output value %q: expected 'format=path'", raw)
+		}
+
+		o := Output{Format: format, Path: path}
+		if format == "template" {
+			if c.Template == "" {
+				closeOutputs(outputs)
+				return fmt.Errorf("--output %s requires '--template' to be specified", raw)
+			}
+			o.Template = c.Template
+		}
+
+		if path == "-" {
+			stdoutSinks++
+			o.Writer = output
+		} else {
+			f, err := os.Create(path)
+			if err != nil {
+				closeOutputs(outputs)
+				return fmt.Errorf("failed to create an output file for %q: %w", raw, err)
+			}
+			o.Writer = f
+		}
+		outputs = append(outputs, o)
+	}
+
+	if stdoutSinks > 1 {
+		closeOutputs(outputs)
+		return fmt.Errorf("only one --output sink may write to stdout ('-'), got %d", stdoutSinks)
+	}
+
+	c.Outputs = outputs
+	c.Output = output
+	c.outputs = nil
+	return nil
+}
+
+// closeOutputs closes every file already opened for outputs, skipping the
+// stdout sink (its *os.File is owned by the caller of Init, not by us). Used
+// to avoid leaking file descriptors when a later --output entry fails to
+// parse or open.
+func closeOutputs(outputs []Output) {
+	for _, o := range outputs {
+		if o.Path == "-" {
+			continue
+		}
+		_ = o.Writer.Close()
+	}
+}
+
+// initIgnorePolicy loads the structured YAML ignore-rules file, if any. It is
+// picked up explicitly via --ignore-policy, or auto-detected when
+// --ignorefile points at a .yaml/.yml file instead of the flat CVE-ID list.
+func (c *ReportOption) initIgnorePolicy(logger *zap.SugaredLogger) error {
+	policyFile := c.IgnorePolicy
+	if policyFile == "" && (strings.HasSuffix(c.IgnoreFile, ".yaml") || strings.HasSuffix(c.IgnoreFile, ".yml")) {
+		policyFile = c.IgnoreFile
+	}
+	if policyFile == "" {
+		return nil
+	}
+
+	rules, err := result.LoadRules(policyFile)
+	if err != nil {
+		return fmt.Errorf("unable to load ignore policy: %w", err)
+	}
+	logger.Debugf("Loaded %d ignore rule(s) from %s", len(rules), policyFile)
+	c.IgnoreRules = rules
+	return nil
+}
+
+// initTracker validates and resolves the issue-tracker sink. Unlike the
+// other formats, issue-tracker doesn't make sense combined with --template,
+// so that combination is rejected outright rather than just logged.
+func (c *ReportOption) initTracker() error {
+	if c.Format != FormatIssueTracker {
+		return nil
+	}
+	if c.Template != "" {
+		return fmt.Errorf("'--template' cannot be used with '--format %s'", FormatIssueTracker)
+	}
+	if c.TrackerConfig == "" {
+		return fmt.Errorf("'--format %s' requires '--tracker-config' to be specified", FormatIssueTracker)
+	}
+
+	cfg, err := tracker.LoadConfig(c.TrackerConfig)
+	if err != nil {
+		return fmt.Errorf("unable to load tracker config: %w", err)
+	}
+
+	t, err := tracker.New(cfg)
+	if err != nil {
+		return fmt.Errorf("unable to initialize tracker: %w", err)
+	}
+	c.Tracker = t
+	return nil
+}
+
+func (c *ReportOption) initLegacyOutput(output *os.File) error {
+	c.Output = output
+	if c.output != "" {
+		f, err := os.Create(c.output)
+		if err != nil {
+			return fmt.Errorf("failed to create an output file: %w", err)
+		}
+		c.Output = f
+	}
+	c.output = ""
+	return nil
+}
+
+// resolveIncludeExclude computes the effective set of values for an
+// include/exclude flag pair as (include, or default when include is empty)
+// \ exclude: an explicit include list replaces the default rather than
+// being merged with it, and everything in exclude is then removed.
+// A value named in both include and exclude is rejected as a conflict, and
+// any value outside knownValues is logged as unknown rather than rejected.
+func (c *ReportOption) resolveIncludeExclude(label, includeStr, excludeStr string, defaults, knownValues []string, logger *zap.SugaredLogger) ([]string, error) {
+	includeList := splitNonEmpty(includeStr)
+	excludeList := splitNonEmpty(excludeStr)
+
+	for _, e := range excludeList {
+		if contains(includeList, e) {
+			return nil, fmt.Errorf("--%s and --exclude-%s conflict on %q", label, label, e)
+		}
+	}
+
+	for _, v := range append(append([]string{}, includeList...), excludeList...) {
+		if !contains(knownValues, v) {
+			logger.Warnf("unknown %s: %s", label, v)
+		}
+	}
+
+	base := defaults
+	if len(includeList) > 0 {
+		base = includeList
+	}
+
+	var effective []string
+	for _, v := range base {
+		if !contains(excludeList, v) {
+			effective = append(effective, v)
+		}
+	}
+	return effective, nil
+}
+
+func splitNonEmpty(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package option
 import (
 	"flag"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -16,20 +17,24 @@ import (
 
 func TestReportReportConfig_Init(t *testing.T) {
 	type fields struct {
-		output         string
-		Format         string
-		Template       string
-		vulnType       string
-		securityChecks string
-		severities     string
-		IgnoreFile     string
-		IgnoreUnfixed  bool
-		listAllPksgs   bool
-		ExitCode       int
-		VulnType       []string
-		Output         *os.File
-		Severities     []dbTypes.Severity
-		debug          bool
+		output                string
+		Format                string
+		Template              string
+		vulnType              string
+		excludeVulnType       string
+		securityChecks        string
+		excludeSecurityChecks string
+		pkgType               string
+		excludePkgType        string
+		severities            string
+		IgnoreFile            string
+		IgnoreUnfixed         bool
+		listAllPksgs          bool
+		ExitCode              int
+		VulnType              []string
+		Output                *os.File
+		Severities            []dbTypes.Severity
+		debug                 bool
 	}
 	tests := []struct {
 		name    string
@@ -115,6 +120,30 @@ func TestReportReportConfig_Init(t *testing.T) {
 				ListAllPkgs:    true,
 			},
 		},
+		{
+			name: "happy path with sarif option list-all-pkgs is false",
+			fields: fields{
+				severities:     "CRITICAL",
+				vulnType:       "os,library",
+				securityChecks: "vuln",
+				Format:         "sarif",
+				listAllPksgs:   false,
+				debug:          true,
+			},
+			args: []string{"centos:7"},
+			logs: []string{
+				"'sarif' automatically enables '--list-all-pkgs'.",
+				"Severities: CRITICAL",
+			},
+			want: ReportOption{
+				Severities:     []dbTypes.Severity{dbTypes.SeverityCritical},
+				VulnType:       []string{types.VulnTypeOS, types.VulnTypeLibrary},
+				SecurityChecks: []string{types.SecurityCheckVulnerability},
+				Format:         "sarif",
+				Output:         os.Stdout,
+				ListAllPkgs:    true,
+			},
+		},
 		{
 			name: "invalid option combination: --template enabled without --format",
 			fields: fields{
@@ -199,6 +228,97 @@ func TestReportReportConfig_Init(t *testing.T) {
 				ListAllPkgs:    true,
 			},
 		},
+		{
+			name: "exclude-only vuln-type and security-checks fall back to defaults minus exclude",
+			fields: fields{
+				severities:            "CRITICAL",
+				excludeVulnType:       "library",
+				excludeSecurityChecks: "config,secret",
+			},
+			args: []string{"alpine:3.10"},
+			want: ReportOption{
+				Severities:     []dbTypes.Severity{dbTypes.SeverityCritical},
+				VulnType:       []string{types.VulnTypeOS},
+				SecurityChecks: []string{types.SecurityCheckVulnerability},
+				Output:         os.Stdout,
+			},
+		},
+		{
+			name: "include and exclude overlap on vuln-type is rejected",
+			fields: fields{
+				severities:      "CRITICAL",
+				vulnType:        "os,library",
+				excludeVulnType: "library",
+				securityChecks:  "vuln",
+			},
+			args:    []string{"alpine:3.10"},
+			wantErr: "conflict",
+		},
+		{
+			name: "exclude-only pkg-type leaves no restriction minus exclude",
+			fields: fields{
+				severities:     "CRITICAL",
+				vulnType:       "os",
+				securityChecks: "vuln",
+				excludePkgType: "npm",
+			},
+			args: []string{"alpine:3.10"},
+			want: ReportOption{
+				Severities:     []dbTypes.Severity{dbTypes.SeverityCritical},
+				VulnType:       []string{types.VulnTypeOS},
+				SecurityChecks: []string{types.SecurityCheckVulnerability},
+				ExcludePkgType: []string{"npm"},
+				Output:         os.Stdout,
+			},
+		},
+		{
+			name: "include and exclude overlap on pkg-type is rejected",
+			fields: fields{
+				severities:     "CRITICAL",
+				vulnType:       "os",
+				securityChecks: "vuln",
+				pkgType:        "npm,gem",
+				excludePkgType: "npm",
+			},
+			args:    []string{"alpine:3.10"},
+			wantErr: "conflict",
+		},
+		{
+			name: "pkg-type has no closed enum so unrecognized values pass through unchanged",
+			fields: fields{
+				severities:     "CRITICAL",
+				vulnType:       "os",
+				securityChecks: "vuln",
+				pkgType:        "not-a-real-ecosystem",
+			},
+			args: []string{"alpine:3.10"},
+			want: ReportOption{
+				Severities:     []dbTypes.Severity{dbTypes.SeverityCritical},
+				VulnType:       []string{types.VulnTypeOS},
+				SecurityChecks: []string{types.SecurityCheckVulnerability},
+				PkgType:        []string{"not-a-real-ecosystem"},
+				Output:         os.Stdout,
+			},
+		},
+		{
+			name: "unknown vuln-type and security-check values are logged but not rejected",
+			fields: fields{
+				severities:     "CRITICAL",
+				vulnType:       "os,dpkg",
+				securityChecks: "vuln,license",
+			},
+			args: []string{"alpine:3.10"},
+			logs: []string{
+				"unknown vuln-type: dpkg",
+				"unknown security-checks: license",
+			},
+			want: ReportOption{
+				Severities:     []dbTypes.Severity{dbTypes.SeverityCritical},
+				VulnType:       []string{types.VulnTypeOS, "dpkg"},
+				SecurityChecks: []string{types.SecurityCheckVulnerability, "license"},
+				Output:         os.Stdout,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -214,17 +334,21 @@ func TestReportReportConfig_Init(t *testing.T) {
 			_ = set.Parse(tt.args)
 
 			c := &ReportOption{
-				output:         tt.fields.output,
-				Format:         tt.fields.Format,
-				Template:       tt.fields.Template,
-				vulnType:       tt.fields.vulnType,
-				securityChecks: tt.fields.securityChecks,
-				severities:     tt.fields.severities,
-				IgnoreFile:     tt.fields.IgnoreFile,
-				IgnoreUnfixed:  tt.fields.IgnoreUnfixed,
-				ExitCode:       tt.fields.ExitCode,
-				ListAllPkgs:    tt.fields.listAllPksgs,
-				Output:         tt.fields.Output,
+				output:                tt.fields.output,
+				Format:                tt.fields.Format,
+				Template:              tt.fields.Template,
+				vulnType:              tt.fields.vulnType,
+				excludeVulnType:       tt.fields.excludeVulnType,
+				securityChecks:        tt.fields.securityChecks,
+				excludeSecurityChecks: tt.fields.excludeSecurityChecks,
+				pkgType:               tt.fields.pkgType,
+				excludePkgType:        tt.fields.excludePkgType,
+				severities:            tt.fields.severities,
+				IgnoreFile:            tt.fields.IgnoreFile,
+				IgnoreUnfixed:         tt.fields.IgnoreUnfixed,
+				ExitCode:              tt.fields.ExitCode,
+				ListAllPkgs:           tt.fields.listAllPksgs,
+				Output:                tt.fields.Output,
 			}
 			err := c.Init(os.Stdout, logger.Sugar())
 
@@ -248,3 +372,163 @@ func TestReportReportConfig_Init(t *testing.T) {
 		})
 	}
 }
+
+func TestReportReportConfig_Init_outputs(t *testing.T) {
+	t.Run("two format=path entries fan out into Outputs", func(t *testing.T) {
+		dir := t.TempDir()
+		jsonPath := filepath.Join(dir, "results.json")
+		sarifPath := filepath.Join(dir, "results.sarif")
+
+		c := &ReportOption{
+			outputs:        []string{"json=" + jsonPath, "sarif=" + sarifPath},
+			severities:     "CRITICAL",
+			vulnType:       "os",
+			securityChecks: "vuln",
+		}
+		require.NoError(t, c.Init(os.Stdout, zap.NewNop().Sugar()))
+
+		require.Len(t, c.Outputs, 2)
+		assert.Equal(t, "json", c.Outputs[0].Format)
+		assert.Equal(t, jsonPath, c.Outputs[0].Path)
+		assert.Equal(t, "sarif", c.Outputs[1].Format)
+		assert.Equal(t, sarifPath, c.Outputs[1].Path)
+	})
+
+	t.Run("two sinks targeting stdout is rejected", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "results.json")
+
+		c := &ReportOption{
+			outputs:        []string{"json=-", "table=-", "sarif=" + path},
+			severities:     "CRITICAL",
+			vulnType:       "os",
+			securityChecks: "vuln",
+		}
+		err := c.Init(os.Stdout, zap.NewNop().Sugar())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "only one --output sink may write to stdout")
+	})
+
+	t.Run("template=path entry without --template is rejected", func(t *testing.T) {
+		dir := t.TempDir()
+		jsonPath := filepath.Join(dir, "results.json")
+		templatePath := filepath.Join(dir, "results.txt")
+
+		c := &ReportOption{
+			outputs:        []string{"json=" + jsonPath, "template=" + templatePath},
+			severities:     "CRITICAL",
+			vulnType:       "os",
+			securityChecks: "vuln",
+		}
+		err := c.Init(os.Stdout, zap.NewNop().Sugar())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "requires '--template'")
+	})
+
+	t.Run("a failed os.Create closes files already opened for earlier entries", func(t *testing.T) {
+		dir := t.TempDir()
+		jsonPath := filepath.Join(dir, "results.json")
+		badPath := filepath.Join(dir, "does-not-exist", "results.sarif")
+
+		before := openFDCount(t)
+
+		c := &ReportOption{
+			outputs:        []string{"json=" + jsonPath, "sarif=" + badPath},
+			severities:     "CRITICAL",
+			vulnType:       "os",
+			securityChecks: "vuln",
+		}
+		err := c.Init(os.Stdout, zap.NewNop().Sugar())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create an output file")
+
+		// The file opened for the first (json) entry before the second
+		// entry failed must have been closed, not leaked.
+		after := openFDCount(t)
+		assert.Equal(t, before, after, "Init must not leak the file descriptor opened for an earlier --output entry")
+	})
+}
+
+// openFDCount returns the number of open file descriptors for this process,
+// skipped on platforms without /proc.
+func openFDCount(t *testing.T) int {
+	t.Helper()
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		t.Skipf("cannot inspect open file descriptors on this platform: %v", err)
+	}
+	return len(entries)
+}
+
+func TestReportReportConfig_Init_ignorePolicy(t *testing.T) {
+	const policy = `
+- vulnerability: CVE-2021-1111
+  reason: accepted risk
+`
+
+	t.Run("auto-detects a .yaml ignorefile as a structured policy", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".trivyignore.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(policy), 0644))
+
+		c := &ReportOption{IgnoreFile: path, severities: "CRITICAL", securityChecks: "vuln"}
+		require.NoError(t, c.Init(os.Stdout, zap.NewNop().Sugar()))
+
+		require.Len(t, c.IgnoreRules, 1)
+		assert.Equal(t, "CVE-2021-1111", c.IgnoreRules[0].Vulnerability)
+	})
+
+	t.Run("auto-detects a .yml ignorefile as a structured policy", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".trivyignore.yml")
+		require.NoError(t, os.WriteFile(path, []byte(policy), 0644))
+
+		c := &ReportOption{IgnoreFile: path, severities: "CRITICAL", securityChecks: "vuln"}
+		require.NoError(t, c.Init(os.Stdout, zap.NewNop().Sugar()))
+
+		require.Len(t, c.IgnoreRules, 1)
+		assert.Equal(t, "CVE-2021-1111", c.IgnoreRules[0].Vulnerability)
+	})
+
+	t.Run("a flat .trivyignore ignorefile is not treated as a policy", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".trivyignore")
+		require.NoError(t, os.WriteFile(path, []byte("CVE-2021-1111\n"), 0644))
+
+		c := &ReportOption{IgnoreFile: path, severities: "CRITICAL", securityChecks: "vuln"}
+		require.NoError(t, c.Init(os.Stdout, zap.NewNop().Sugar()))
+
+		assert.Empty(t, c.IgnoreRules)
+	})
+
+	t.Run("--ignore-policy takes precedence over IgnoreFile", func(t *testing.T) {
+		dir := t.TempDir()
+		ignoreFilePath := filepath.Join(dir, ".trivyignore.yaml")
+		require.NoError(t, os.WriteFile(ignoreFilePath, []byte(policy), 0644))
+
+		policyPath := filepath.Join(dir, "policy.yaml")
+		require.NoError(t, os.WriteFile(policyPath, []byte(`
+- vulnerability: CVE-2021-9999
+  reason: from --ignore-policy
+`), 0644))
+
+		c := &ReportOption{
+			IgnoreFile:     ignoreFilePath,
+			IgnorePolicy:   policyPath,
+			severities:     "CRITICAL",
+			securityChecks: "vuln",
+		}
+		require.NoError(t, c.Init(os.Stdout, zap.NewNop().Sugar()))
+
+		require.Len(t, c.IgnoreRules, 1)
+		assert.Equal(t, "CVE-2021-9999", c.IgnoreRules[0].Vulnerability)
+	})
+
+	t.Run("a missing --ignore-policy file is an error", func(t *testing.T) {
+		c := &ReportOption{
+			IgnorePolicy:   filepath.Join(t.TempDir(), "does-not-exist.yaml"),
+			severities:     "CRITICAL",
+			securityChecks: "vuln",
+		}
+		err := c.Init(os.Stdout, zap.NewNop().Sugar())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unable to load ignore policy")
+	})
+}
@@ -0,0 +1,163 @@
+// Package sarif renders scan results as a SARIF 2.1.0 log, the format
+// consumed by GitHub code scanning and most other CI security dashboards.
+package sarif
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+const (
+	schemaURI = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+	version   = "2.1.0"
+	toolName  = "Trivy"
+)
+
+// log is the minimal SARIF 2.1.0 structure trivy emits: one run, one rule
+// per distinct vulnerability ID, and one result per finding.
+type log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []run  `json:"runs"`
+}
+
+type run struct {
+	Tool    tool     `json:"tool"`
+	Results []result `json:"results"`
+}
+
+type tool struct {
+	Driver driver `json:"driver"`
+}
+
+type driver struct {
+	Name  string `json:"name"`
+	Rules []rule `json:"rules"`
+}
+
+type rule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type result struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             message           `json:"message"`
+	Locations           []location        `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Suppressions        []suppression     `json:"suppressions,omitempty"`
+}
+
+type message struct {
+	Text string `json:"text"`
+}
+
+type location struct {
+	PhysicalLocation physicalLocation `json:"physicalLocation"`
+}
+
+type physicalLocation struct {
+	ArtifactLocation artifactLocation `json:"artifactLocation"`
+}
+
+type artifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type suppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// Write renders results as a SARIF 2.1.0 log to w. Ignored matches are
+// included as suppressed results rather than dropped, so a SARIF consumer
+// still sees that a rule fired and why it was waived.
+func Write(w io.Writer, results []types.Result) error {
+	rules := map[string]struct{}{}
+	var sarifResults []result
+
+	for _, r := range results {
+		for _, f := range r.Vulnerabilities {
+			rules[f.VulnerabilityID] = struct{}{}
+			sarifResults = append(sarifResults, toResult(r.Target, f, nil))
+		}
+		for _, im := range r.IgnoredMatches {
+			rules[im.VulnerabilityID] = struct{}{}
+			sarifResults = append(sarifResults, toResult(r.Target, im.Finding, &im))
+		}
+	}
+
+	l := log{
+		Schema:  schemaURI,
+		Version: version,
+		Runs: []run{
+			{
+				Tool:    tool{Driver: driver{Name: toolName, Rules: toRules(rules)}},
+				Results: sarifResults,
+			},
+		},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(l)
+}
+
+// toRules converts the set of rule IDs into a stable, sorted slice so the
+// output is reproducible across runs with the same input.
+func toRules(ids map[string]struct{}) []rule {
+	rules := make([]rule, 0, len(ids))
+	for id := range ids {
+		rules = append(rules, rule{ID: id, Name: id})
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	return rules
+}
+
+func toResult(target string, f types.Finding, ignored *types.IgnoredMatch) result {
+	r := result{
+		RuleID:  f.VulnerabilityID,
+		Level:   levelFor(f.Severity),
+		Message: message{Text: f.VulnerabilityID + " in " + f.PkgName},
+		Locations: []location{
+			{PhysicalLocation: physicalLocation{ArtifactLocation: artifactLocation{URI: f.FilePath}}},
+		},
+		PartialFingerprints: map[string]string{
+			"trivyFingerprintV1": fingerprint(target, f),
+		},
+	}
+	if ignored != nil {
+		r.Suppressions = []suppression{
+			{Kind: "external", Justification: ignored.Reason},
+		}
+	}
+	return r
+}
+
+// levelFor maps a trivy severity to the SARIF result.level enum.
+func levelFor(severity string) string {
+	switch strings.ToUpper(severity) {
+	case "CRITICAL", "HIGH":
+		return "error"
+	case "MEDIUM":
+		return "warning"
+	case "LOW", "UNKNOWN":
+		return "note"
+	default:
+		return "warning"
+	}
+}
+
+// fingerprint is a stable per-finding identifier used for de-duplication
+// across runs, independent of ordering in the results slice.
+func fingerprint(target string, f types.Finding) string {
+	sum := sha256.Sum256([]byte(f.VulnerabilityID + "|" + f.PkgName + "|" + target))
+	return hex.EncodeToString(sum[:])
+}
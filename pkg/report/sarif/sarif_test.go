@@ -0,0 +1,81 @@
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestWrite(t *testing.T) {
+	results := []types.Result{
+		{
+			Target: "alpine:3.10",
+			Vulnerabilities: []types.Finding{
+				{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl", Severity: "HIGH", FilePath: "/lib/libssl.so"},
+			},
+			IgnoredMatches: []types.IgnoredMatch{
+				{
+					Finding: types.Finding{VulnerabilityID: "CVE-2021-2222", PkgName: "curl", Severity: "LOW"},
+					Reason:  "accepted risk",
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, results))
+
+	var got log
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+
+	assert.Equal(t, version, got.Version)
+	require.Len(t, got.Runs, 1)
+	require.Len(t, got.Runs[0].Results, 2)
+
+	var vulnResult, suppressedResult result
+	for _, r := range got.Runs[0].Results {
+		if r.RuleID == "CVE-2021-1111" {
+			vulnResult = r
+		} else {
+			suppressedResult = r
+		}
+	}
+
+	assert.Equal(t, "error", vulnResult.Level)
+	assert.Empty(t, vulnResult.Suppressions)
+
+	require.Len(t, suppressedResult.Suppressions, 1)
+	assert.Equal(t, "external", suppressedResult.Suppressions[0].Kind)
+	assert.Equal(t, "accepted risk", suppressedResult.Suppressions[0].Justification)
+}
+
+func TestWrite_rulesAreSortedForReproducibility(t *testing.T) {
+	results := []types.Result{
+		{
+			Target: "alpine:3.10",
+			Vulnerabilities: []types.Finding{
+				{VulnerabilityID: "CVE-2021-3333", PkgName: "zlib"},
+				{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl"},
+				{VulnerabilityID: "CVE-2021-2222", PkgName: "curl"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, results))
+
+	var got log
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+
+	require.Len(t, got.Runs, 1)
+	var ids []string
+	for _, r := range got.Runs[0].Tool.Driver.Rules {
+		ids = append(ids, r.ID)
+	}
+	assert.Equal(t, []string{"CVE-2021-1111", "CVE-2021-2222", "CVE-2021-3333"}, ids)
+}
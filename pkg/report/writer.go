@@ -0,0 +1,167 @@
+// Package report fans scan results out to whatever sink(s) a ReportOption
+// was configured with: one or more --output files, each in its own format.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/aquasecurity/trivy/pkg/commands/option"
+	"github.com/aquasecurity/trivy/pkg/report/sarif"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// Write renders results into every sink configured on opt: the legacy
+// single Output when Outputs is empty (--output given at most once, as a
+// plain path), or every entry in Outputs when --output was repeated or
+// used with format=path syntax. The configured
+// ignore rules and --ignore-unfixed are applied first, so every sink sees
+// the same, already-filtered set of findings. When Format is
+// option.FormatIssueTracker, results are synced to the tracker instead of
+// being written to a file. logger surfaces warnings raised while applying
+// ignore rules, such as an expired rule that no longer suppresses anything.
+func Write(opt *option.ReportOption, results []types.Result, logger *zap.SugaredLogger) error {
+	results = applyIgnores(opt, results, logger)
+
+	if opt.Format == option.FormatIssueTracker {
+		return syncTracker(opt, results)
+	}
+
+	if len(opt.Outputs) == 0 {
+		return writeTo(opt.Output, opt.Format, results)
+	}
+
+	for _, o := range opt.Outputs {
+		if err := writeTo(o.Writer, o.Format, results); err != nil {
+			return fmt.Errorf("failed to write %s report to %s: %w", o.Format, o.Path, err)
+		}
+		// "-" is the stdout sink; initOutputs pointed o.Writer at whatever
+		// *os.File Init was given for stdout, which in tests is not the
+		// package-level os.Stdout, so compare by Path rather than identity.
+		if o.Path != "-" {
+			if err := o.Writer.Close(); err != nil {
+				return fmt.Errorf("failed to close %s: %w", o.Path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// applyIgnores suppresses findings per opt.IgnoreRules and, when
+// --ignore-unfixed is set, any remaining finding with no fixed version.
+// Suppressed findings are moved to IgnoredMatches rather than dropped, so
+// report formats that surface ignored findings (e.g. sarif suppressions)
+// still see them.
+func applyIgnores(opt *option.ReportOption, results []types.Result, logger *zap.SugaredLogger) []types.Result {
+	if len(opt.IgnoreRules) == 0 && !opt.IgnoreUnfixed {
+		return results
+	}
+
+	now := time.Now()
+	opt.IgnoreRules.WarnExpired(now, func(msg string) { logger.Warn(msg) })
+
+	out := make([]types.Result, len(results))
+	for i, r := range results {
+		// warn is nil here: the expired-rule warning already ran once above,
+		// not once per target.
+		kept, ignored := opt.IgnoreRules.Filter(r.Vulnerabilities, now, nil)
+
+		if opt.IgnoreUnfixed {
+			var stillKept []types.Finding
+			for _, f := range kept {
+				if f.FixedVersion == "" {
+					ignored = append(ignored, types.IgnoredMatch{Finding: f, Reason: "no fixed version available (--ignore-unfixed)"})
+					continue
+				}
+				stillKept = append(stillKept, f)
+			}
+			kept = stillKept
+		}
+
+		out[i] = types.Result{
+			Target:          r.Target,
+			Vulnerabilities: kept,
+			IgnoredMatches:  append(r.IgnoredMatches, ignored...),
+		}
+	}
+	return out
+}
+
+// syncTracker files or updates a tracker issue for every vulnerability left
+// after ignore filtering. Unlike the file-based sinks, there is nothing to
+// fan out to: a single tracker is resolved once at Init and every finding
+// across every target is synced to it in turn.
+func syncTracker(opt *option.ReportOption, results []types.Result) error {
+	for _, r := range results {
+		for _, f := range r.Vulnerabilities {
+			if err := opt.Tracker.Sync(r.Target, f); err != nil {
+				return fmt.Errorf("failed to sync %s (%s) to tracker: %w", f.VulnerabilityID, r.Target, err)
+			}
+		}
+	}
+	return nil
+}
+
+// writeTo renders results in the given format to w. Each format named in
+// option.ReportOption.Init's format switch needs a case here; an
+// unrecognized format is a configuration error, not a silent fallback to
+// JSON, since a mislabeled report (e.g. "table" coming out as raw JSON) is
+// worse than failing the scan outright.
+func writeTo(w *os.File, format string, results []types.Result) error {
+	switch format {
+	case "", "json":
+		// empty Format is the legacy default (--format was never given),
+		// which has always meant JSON for the single-output path.
+		return writeJSON(w, results)
+	case "sarif":
+		return sarif.Write(w, results)
+	case "table":
+		return writeTable(w, results)
+	case "cyclonedx", "spdx", "spdx-json", "template":
+		return fmt.Errorf("report format %q is not yet supported by the multi-output writer", format)
+	default:
+		return fmt.Errorf("unknown report format %q", format)
+	}
+}
+
+func writeJSON(w *os.File, results []types.Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
+
+// writeTable renders a human-readable summary: one row per finding, grouped
+// under its target, plus a trailing count of findings suppressed by ignore
+// rules or --ignore-unfixed.
+func writeTable(w *os.File, results []types.Result) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, r := range results {
+		if len(r.Vulnerabilities) == 0 {
+			continue
+		}
+		fmt.Fprintf(tw, "%s\n", r.Target)
+		fmt.Fprintln(tw, "PACKAGE\tVULNERABILITY ID\tSEVERITY\tINSTALLED VERSION\tFIXED VERSION")
+		for _, f := range r.Vulnerabilities {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", f.PkgName, f.VulnerabilityID, f.Severity, f.PkgVersion, f.FixedVersion)
+		}
+		fmt.Fprintln(tw)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	var ignored int
+	for _, r := range results {
+		ignored += len(r.IgnoredMatches)
+	}
+	if ignored > 0 {
+		_, err := fmt.Fprintf(w, "%d finding(s) suppressed by ignore rules\n", ignored)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/aquasecurity/trivy/pkg/commands/option"
+	"github.com/aquasecurity/trivy/pkg/result"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func nopLogger() *zap.SugaredLogger {
+	return zap.NewNop().Sugar()
+}
+
+func TestWrite_fansOutToEachOutput(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "results.json")
+	sarifPath := filepath.Join(dir, "results.sarif")
+
+	jsonFile, err := os.Create(jsonPath)
+	require.NoError(t, err)
+	sarifFile, err := os.Create(sarifPath)
+	require.NoError(t, err)
+
+	opt := &option.ReportOption{
+		Outputs: []option.Output{
+			{Format: "json", Path: jsonPath, Writer: jsonFile},
+			{Format: "sarif", Path: sarifPath, Writer: sarifFile},
+		},
+	}
+
+	results := []types.Result{
+		{
+			Target:          "alpine:3.10",
+			Vulnerabilities: []types.Finding{{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl", Severity: "HIGH"}},
+		},
+	}
+
+	require.NoError(t, Write(opt, results, nopLogger()))
+
+	jsonBytes, err := os.ReadFile(jsonPath)
+	require.NoError(t, err)
+	var gotJSON []types.Result
+	require.NoError(t, json.Unmarshal(jsonBytes, &gotJSON))
+	assert.Equal(t, results, gotJSON)
+
+	sarifBytes, err := os.ReadFile(sarifPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(sarifBytes), "CVE-2021-1111")
+	assert.Contains(t, string(sarifBytes), `"version": "2.1.0"`)
+}
+
+func TestWrite_appliesIgnoreRulesAndIgnoreUnfixed(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.json")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+
+	opt := &option.ReportOption{
+		Output:        f,
+		IgnoreUnfixed: true,
+		IgnoreRules: result.Rules{
+			{Vulnerability: "CVE-2021-1111", Reason: "accepted risk"},
+		},
+	}
+
+	results := []types.Result{
+		{
+			Target: "alpine:3.10",
+			Vulnerabilities: []types.Finding{
+				{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl", FixedVersion: "1.1.1"}, // suppressed by rule
+				{VulnerabilityID: "CVE-2021-2222", PkgName: "curl"},                           // suppressed by --ignore-unfixed (no FixedVersion)
+				{VulnerabilityID: "CVE-2021-3333", PkgName: "bash", FixedVersion: "5.1"},      // kept
+			},
+		},
+	}
+
+	require.NoError(t, Write(opt, results, nopLogger()))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var got []types.Result
+	require.NoError(t, json.Unmarshal(b, &got))
+
+	require.Len(t, got, 1)
+	require.Len(t, got[0].Vulnerabilities, 1)
+	assert.Equal(t, "CVE-2021-3333", got[0].Vulnerabilities[0].VulnerabilityID)
+
+	require.Len(t, got[0].IgnoredMatches, 2)
+	var reasons []string
+	for _, im := range got[0].IgnoredMatches {
+		reasons = append(reasons, im.Reason)
+	}
+	assert.Contains(t, reasons, "accepted risk")
+	assert.Contains(t, reasons, "no fixed version available (--ignore-unfixed)")
+}
+
+type mockTracker struct {
+	synced []string
+	err    error
+}
+
+func (m *mockTracker) Sync(target string, f types.Finding) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.synced = append(m.synced, target+"|"+f.VulnerabilityID)
+	return nil
+}
+
+func TestWrite_syncsTrackerInsteadOfWritingAFile(t *testing.T) {
+	mock := &mockTracker{}
+	opt := &option.ReportOption{
+		Format:  option.FormatIssueTracker,
+		Tracker: mock,
+	}
+
+	results := []types.Result{
+		{
+			Target: "alpine:3.10",
+			Vulnerabilities: []types.Finding{
+				{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl"},
+				{VulnerabilityID: "CVE-2021-2222", PkgName: "curl"},
+			},
+		},
+	}
+
+	require.NoError(t, Write(opt, results, nopLogger()))
+	assert.Equal(t, []string{"alpine:3.10|CVE-2021-1111", "alpine:3.10|CVE-2021-2222"}, mock.synced)
+}
+
+func TestWrite_trackerSyncErrorIsWrapped(t *testing.T) {
+	mock := &mockTracker{err: errors.New("boom")}
+	opt := &option.ReportOption{
+		Format:  option.FormatIssueTracker,
+		Tracker: mock,
+	}
+
+	results := []types.Result{
+		{Target: "alpine:3.10", Vulnerabilities: []types.Finding{{VulnerabilityID: "CVE-2021-1111"}}},
+	}
+
+	err := Write(opt, results, nopLogger())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "CVE-2021-1111")
+	assert.Contains(t, err.Error(), "boom")
+}
+
+func TestWrite_singleLegacyOutput(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.json")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+
+	opt := &option.ReportOption{Output: f}
+	results := []types.Result{{Target: "centos:7"}}
+
+	require.NoError(t, Write(opt, results, nopLogger()))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var got []types.Result
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, results, got)
+}
+
+func TestWrite_tableFormat(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.txt")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+
+	opt := &option.ReportOption{
+		Outputs: []option.Output{{Format: "table", Path: path, Writer: f}},
+	}
+	results := []types.Result{
+		{
+			Target:          "alpine:3.10",
+			Vulnerabilities: []types.Finding{{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl", Severity: "HIGH"}},
+		},
+	}
+
+	require.NoError(t, Write(opt, results, nopLogger()))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	out := string(b)
+	assert.Contains(t, out, "alpine:3.10")
+	assert.Contains(t, out, "openssl")
+	assert.Contains(t, out, "CVE-2021-1111")
+}
+
+func TestWrite_stdoutSinkIsNotClosed(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "results.json")
+	jsonFile, err := os.Create(jsonPath)
+	require.NoError(t, err)
+
+	// Stand in for the CLI's injected stdout with an ordinary file, not the
+	// package-level os.Stdout, to prove the "-" sink is identified by Path
+	// rather than by identity against os.Stdout.
+	stdoutPath := filepath.Join(dir, "stdout")
+	stdout, err := os.Create(stdoutPath)
+	require.NoError(t, err)
+
+	opt := &option.ReportOption{
+		Outputs: []option.Output{
+			{Format: "json", Path: jsonPath, Writer: jsonFile},
+			{Format: "json", Path: "-", Writer: stdout},
+		},
+	}
+	results := []types.Result{{Target: "alpine:3.10"}}
+
+	require.NoError(t, Write(opt, results, nopLogger()))
+
+	// If Write had closed stdout, writing to it again would fail.
+	_, err = stdout.WriteString("still open\n")
+	assert.NoError(t, err)
+}
+
+func TestWrite_unsupportedFormatIsAnError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.xml")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+
+	opt := &option.ReportOption{
+		Outputs: []option.Output{{Format: "cyclonedx", Path: path, Writer: f}},
+	}
+	results := []types.Result{{Target: "alpine:3.10"}}
+
+	err = Write(opt, results, nopLogger())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cyclonedx")
+}
+
+func TestWrite_warnsOnExpiredIgnoreRule(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.json")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+
+	core, obs := observer.New(zap.InfoLevel)
+	logger := zap.New(core).Sugar()
+
+	past := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	opt := &option.ReportOption{
+		Output: f,
+		IgnoreRules: result.Rules{
+			{Vulnerability: "CVE-2021-1111", Expires: &past},
+		},
+	}
+	results := []types.Result{
+		{Target: "alpine:3.10", Vulnerabilities: []types.Finding{{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl"}}},
+	}
+
+	require.NoError(t, Write(opt, results, logger))
+
+	var messages []string
+	for _, entry := range obs.AllUntimed() {
+		messages = append(messages, entry.Message)
+	}
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "expired")
+}
+
+func TestWrite_warnsOnExpiredIgnoreRuleOncePerRunNotPerTarget(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.json")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+
+	core, obs := observer.New(zap.InfoLevel)
+	logger := zap.New(core).Sugar()
+
+	past := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	opt := &option.ReportOption{
+		Output: f,
+		IgnoreRules: result.Rules{
+			{Vulnerability: "CVE-2021-1111", Expires: &past},
+		},
+	}
+	results := []types.Result{
+		{Target: "alpine:3.10", Vulnerabilities: []types.Finding{{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl"}}},
+		{Target: "centos:7", Vulnerabilities: []types.Finding{{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl"}}},
+		{Target: "debian:11", Vulnerabilities: []types.Finding{{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl"}}},
+	}
+
+	require.NoError(t, Write(opt, results, logger))
+
+	var messages []string
+	for _, entry := range obs.AllUntimed() {
+		messages = append(messages, entry.Message)
+	}
+	require.Len(t, messages, 1, "the expired-rule warning must be logged once per run, not once per scan target")
+}
@@ -0,0 +1,155 @@
+package result
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// ValidFixStates enumerates the recognized fix-state values for an ignore rule.
+var ValidFixStates = []string{"fixed", "not-fixed", "unknown", "wont-fix"}
+
+// Rule is a single structured ignore-rule predicate loaded from a YAML
+// ignore-policy file. A finding is suppressed only when every field set on
+// the rule matches; fields left empty act as wildcards.
+type Rule struct {
+	Vulnerability string     `yaml:"vulnerability"`
+	Package       string     `yaml:"package"`
+	PackageType   string     `yaml:"package-type"`
+	FixState      string     `yaml:"fix-state"`
+	Severity      []string   `yaml:"severity"`
+	Path          string     `yaml:"path"`
+	Expires       *time.Time `yaml:"expires"`
+	Reason        string     `yaml:"reason"`
+}
+
+// Rules is a compiled, ordered set of ignore rules.
+type Rules []Rule
+
+// LoadRules reads and validates a YAML ignore-policy file.
+func LoadRules(filePath string) (Rules, error) {
+	b, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ignore policy file: %w", err)
+	}
+
+	var rules Rules
+	if err := yaml.Unmarshal(b, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse ignore policy file: %w", err)
+	}
+
+	for i, r := range rules {
+		if r.FixState == "" {
+			continue
+		}
+		if !containsFold(ValidFixStates, r.FixState) {
+			return nil, fmt.Errorf("ignore rule %d: unknown fix-state %q", i, r.FixState)
+		}
+		// Findings carry FixState in its canonical lowercase form (see
+		// types.Finding), so normalize here too rather than comparing
+		// case-insensitively on every Match call.
+		rules[i].FixState = strings.ToLower(r.FixState)
+	}
+
+	return rules, nil
+}
+
+// Expired reports whether the rule's expiry date, if any, has already passed.
+func (r Rule) Expired(now time.Time) bool {
+	return r.Expires != nil && now.After(*r.Expires)
+}
+
+// Match reports whether the rule applies to the given finding as of now. An
+// expired rule never matches.
+func (r Rule) Match(f types.Finding, now time.Time) bool {
+	if r.Expired(now) {
+		return false
+	}
+	if r.Vulnerability != "" && !globMatch(r.Vulnerability, f.VulnerabilityID) {
+		return false
+	}
+	if r.Package != "" && !globMatch(r.Package, f.PkgName) {
+		return false
+	}
+	if r.PackageType != "" && r.PackageType != f.PkgType {
+		return false
+	}
+	if r.FixState != "" && r.FixState != f.FixState {
+		return false
+	}
+	if len(r.Severity) > 0 && !containsFold(r.Severity, f.Severity) {
+		return false
+	}
+	if r.Path != "" && !globMatch(r.Path, f.FilePath) {
+		return false
+	}
+	return true
+}
+
+// WarnExpired reports every expired rule via warn, once each. Callers that
+// invoke Filter once per scan target should call this separately, once per
+// overall run, rather than relying on Filter's own warning (which fires on
+// every call and would otherwise repeat the same message per target).
+func (rules Rules) WarnExpired(now time.Time, warn func(string)) {
+	if warn == nil {
+		return
+	}
+	for _, r := range rules {
+		if r.Expired(now) {
+			warn(fmt.Sprintf("ignore rule for vulnerability %q has expired and will no longer be applied", r.Vulnerability))
+		}
+	}
+}
+
+// Filter splits findings into those that still apply and those suppressed by
+// a rule, recording the suppressing rule's reason on each ignored finding.
+// Expired rules are reported via warn rather than silently skipped; pass nil
+// if the caller has already warned about expired rules itself (e.g. once per
+// run via WarnExpired, rather than once per call to Filter).
+func (rules Rules) Filter(findings []types.Finding, now time.Time, warn func(string)) ([]types.Finding, []types.IgnoredMatch) {
+	rules.WarnExpired(now, warn)
+
+	var kept []types.Finding
+	var ignored []types.IgnoredMatch
+	for _, f := range findings {
+		if r, ok := rules.firstMatch(f, now); ok {
+			ignored = append(ignored, types.IgnoredMatch{Finding: f, Reason: r.Reason})
+			continue
+		}
+		kept = append(kept, f)
+	}
+
+	return kept, ignored
+}
+
+func (rules Rules) firstMatch(f types.Finding, now time.Time) (Rule, bool) {
+	for _, r := range rules {
+		if r.Match(f, now) {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+func globMatch(pattern, name string) bool {
+	ok, err := path.Match(pattern, name)
+	if err != nil {
+		return pattern == name
+	}
+	return ok
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
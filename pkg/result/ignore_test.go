@@ -0,0 +1,177 @@
+package result
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestRule_Match(t *testing.T) {
+	past := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	future := time.Date(2999, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	finding := types.Finding{
+		VulnerabilityID: "CVE-2021-1234",
+		PkgName:         "openssl",
+		PkgType:         "os",
+		FixState:        "not-fixed",
+		Severity:        "HIGH",
+		FilePath:        "/usr/lib/openssl/libssl.so",
+	}
+
+	tests := []struct {
+		name string
+		rule Rule
+		want bool
+	}{
+		{
+			name: "exact match on all fields",
+			rule: Rule{
+				Vulnerability: "CVE-2021-1234",
+				Package:       "openssl",
+				PackageType:   "os",
+				FixState:      "not-fixed",
+				Severity:      []string{"HIGH"},
+				Path:          "/usr/lib/openssl/libssl.so",
+			},
+			want: true,
+		},
+		{
+			name: "vulnerability glob matches",
+			rule: Rule{Vulnerability: "CVE-2021-*"},
+			want: true,
+		},
+		{
+			name: "vulnerability glob does not match",
+			rule: Rule{Vulnerability: "CVE-2022-*"},
+			want: false,
+		},
+		{
+			name: "path glob matches",
+			rule: Rule{Path: "/usr/lib/openssl/*"},
+			want: true,
+		},
+		{
+			name: "path glob does not match",
+			rule: Rule{Path: "/usr/lib/other/*"},
+			want: false,
+		},
+		{
+			name: "severity list matches case-insensitively",
+			rule: Rule{Severity: []string{"high", "critical"}},
+			want: true,
+		},
+		{
+			name: "fix-state mismatch",
+			rule: Rule{FixState: "fixed"},
+			want: false,
+		},
+		{
+			name: "expired rule never matches",
+			rule: Rule{Vulnerability: "CVE-2021-1234", Expires: &past},
+			want: false,
+		},
+		{
+			name: "unexpired rule still matches",
+			rule: Rule{Vulnerability: "CVE-2021-1234", Expires: &future},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.Match(finding, now), tt.name)
+		})
+	}
+}
+
+func TestRules_Filter(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	findings := []types.Finding{
+		{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl", FixState: "not-fixed"},
+		{VulnerabilityID: "CVE-2021-2222", PkgName: "curl", FixState: "fixed"},
+	}
+
+	rules := Rules{
+		{Vulnerability: "CVE-2021-1111", Reason: "accepted risk"},
+	}
+
+	var warnings []string
+	kept, ignored := rules.Filter(findings, now, func(msg string) { warnings = append(warnings, msg) })
+
+	assert.Empty(t, warnings)
+	assert.Equal(t, []types.Finding{findings[1]}, kept)
+	assert.Equal(t, []types.IgnoredMatch{{Finding: findings[0], Reason: "accepted risk"}}, ignored)
+}
+
+func TestLoadRules_normalizesFixStateCase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+- vulnerability: CVE-2021-1111
+  fix-state: Fixed
+`), 0644))
+
+	rules, err := LoadRules(path)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, "fixed", rules[0].FixState)
+
+	finding := types.Finding{VulnerabilityID: "CVE-2021-1111", FixState: "fixed"}
+	assert.True(t, rules[0].Match(finding, time.Now()), "rule written with mixed-case fix-state should still match the scanner's lowercase value")
+}
+
+func TestRules_Filter_nilWarnIsSafe(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	past := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	findings := []types.Finding{
+		{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl", FixState: "not-fixed"},
+	}
+	rules := Rules{
+		{Vulnerability: "CVE-2021-1111", Expires: &past},
+	}
+
+	kept, ignored := rules.Filter(findings, now, nil)
+	assert.Equal(t, findings, kept)
+	assert.Empty(t, ignored)
+}
+
+func TestRules_WarnExpired_onePerRule(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	past := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	future := time.Date(2999, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rules := Rules{
+		{Vulnerability: "CVE-2021-1111", Expires: &past},
+		{Vulnerability: "CVE-2021-2222", Expires: &future},
+	}
+
+	var warnings []string
+	rules.WarnExpired(now, func(msg string) { warnings = append(warnings, msg) })
+
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "CVE-2021-1111")
+}
+
+func TestRules_Filter_expiredRuleWarns(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	past := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	findings := []types.Finding{
+		{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl", FixState: "not-fixed"},
+	}
+	rules := Rules{
+		{Vulnerability: "CVE-2021-1111", Expires: &past},
+	}
+
+	var warnings []string
+	kept, ignored := rules.Filter(findings, now, func(msg string) { warnings = append(warnings, msg) })
+
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, findings, kept)
+	assert.Empty(t, ignored)
+}
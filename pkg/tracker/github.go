@@ -0,0 +1,120 @@
+package tracker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+type githubTracker struct {
+	cfg    *Config
+	client *http.Client
+}
+
+func newGitHubTracker(cfg *Config) *githubTracker {
+	return &githubTracker{cfg: cfg, client: newHTTPClient()}
+}
+
+func (t *githubTracker) baseURL() string {
+	if t.cfg.BaseURL != "" {
+		return t.cfg.BaseURL
+	}
+	return "https://api.github.com"
+}
+
+// Sync opens or updates a GitHub issue for the finding, keyed by its
+// fingerprint label via the repo issues search API.
+func (t *githubTracker) Sync(target string, f types.Finding) error {
+	fingerprint := Fingerprint(target, f)
+
+	existing, err := t.findIssue(fingerprint)
+	if err != nil {
+		return err
+	}
+	if existing != 0 {
+		return t.updateIssue(existing, f)
+	}
+	return t.createIssue(fingerprint, f)
+}
+
+func (t *githubTracker) findIssue(fingerprint string) (int, error) {
+	url := fmt.Sprintf("%s/search/issues?q=repo:%s+label:%s+label:%s", t.baseURL(), t.cfg.Project, t.cfg.Label, fingerprint)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	t.authenticate(req)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to search GitHub issues: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Items []struct {
+			Number int `json:"number"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode GitHub search response: %w", err)
+	}
+	if len(result.Items) == 0 {
+		return 0, nil
+	}
+	return result.Items[0].Number, nil
+}
+
+func (t *githubTracker) createIssue(fingerprint string, f types.Finding) error {
+	body := map[string]any{
+		"title":  issueTitle(f),
+		"body":   issueBody(f),
+		"labels": []string{t.cfg.Label, fingerprint},
+	}
+	return t.post(fmt.Sprintf("%s/repos/%s/issues", t.baseURL(), t.cfg.Project), body)
+}
+
+func (t *githubTracker) updateIssue(number int, f types.Finding) error {
+	body := map[string]any{"body": issueBody(f)}
+	return t.patch(fmt.Sprintf("%s/repos/%s/issues/%d", t.baseURL(), t.cfg.Project, number), body)
+}
+
+func (t *githubTracker) authenticate(req *http.Request) {
+	req.Header.Set("Authorization", "token "+t.cfg.Token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+}
+
+func (t *githubTracker) post(url string, body map[string]any) error {
+	return t.send(http.MethodPost, url, body)
+}
+
+func (t *githubTracker) patch(url string, body map[string]any) error {
+	return t.send(http.MethodPatch, url, body)
+}
+
+func (t *githubTracker) send(method, url string, body map[string]any) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(method, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	t.authenticate(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call GitHub API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package tracker
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestGitHubTracker_Sync_createsIssueWhenNoneExists(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/search/issues":
+			fmt.Fprint(w, `{"items": []}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/owner/repo/issues":
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	tr := newGitHubTracker(&Config{BaseURL: server.URL, Token: "tok", Project: "owner/repo", Label: "trivy"})
+
+	err := tr.Sync("alpine:3.10", types.Finding{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl", PkgVersion: "1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GET /search/issues", "POST /repos/owner/repo/issues"}, calls)
+}
+
+func TestGitHubTracker_Sync_updatesExistingIssueInsteadOfCreating(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/search/issues":
+			fmt.Fprint(w, `{"items": [{"number": 42}]}`)
+		case r.Method == http.MethodPatch && r.URL.Path == "/repos/owner/repo/issues/42":
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	tr := newGitHubTracker(&Config{BaseURL: server.URL, Token: "tok", Project: "owner/repo", Label: "trivy"})
+
+	err := tr.Sync("alpine:3.10", types.Finding{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl", PkgVersion: "1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GET /search/issues", "PATCH /repos/owner/repo/issues/42"}, calls)
+}
+
+func TestGitHubTracker_Sync_nonSuccessStatusIsAnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `{"items": []}`)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	tr := newGitHubTracker(&Config{BaseURL: server.URL, Token: "tok", Project: "owner/repo", Label: "trivy"})
+
+	err := tr.Sync("alpine:3.10", types.Finding{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "500")
+}
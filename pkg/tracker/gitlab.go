@@ -0,0 +1,111 @@
+package tracker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+type gitlabTracker struct {
+	cfg    *Config
+	client *http.Client
+}
+
+func newGitLabTracker(cfg *Config) *gitlabTracker {
+	return &gitlabTracker{cfg: cfg, client: newHTTPClient()}
+}
+
+func (t *gitlabTracker) baseURL() string {
+	if t.cfg.BaseURL != "" {
+		return t.cfg.BaseURL
+	}
+	return "https://gitlab.com"
+}
+
+// Sync opens or updates a GitLab issue for the finding, keyed by its
+// fingerprint label.
+func (t *gitlabTracker) Sync(target string, f types.Finding) error {
+	fingerprint := Fingerprint(target, f)
+
+	existing, err := t.findIssue(fingerprint)
+	if err != nil {
+		return err
+	}
+	if existing != 0 {
+		return t.updateIssue(existing, f)
+	}
+	return t.createIssue(fingerprint, f)
+}
+
+func (t *gitlabTracker) findIssue(fingerprint string) (int, error) {
+	u := fmt.Sprintf("%s/api/v4/projects/%s/issues?labels=%s,%s",
+		t.baseURL(), url.PathEscape(t.cfg.Project), t.cfg.Label, fingerprint)
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return 0, err
+	}
+	t.authenticate(req)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to search GitLab issues: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var issues []struct {
+		IID int `json:"iid"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&issues); err != nil {
+		return 0, fmt.Errorf("failed to decode GitLab issue search response: %w", err)
+	}
+	if len(issues) == 0 {
+		return 0, nil
+	}
+	return issues[0].IID, nil
+}
+
+func (t *gitlabTracker) createIssue(fingerprint string, f types.Finding) error {
+	body := map[string]any{
+		"title":       issueTitle(f),
+		"description": issueBody(f),
+		"labels":      fmt.Sprintf("%s,%s", t.cfg.Label, fingerprint),
+	}
+	return t.send(http.MethodPost, fmt.Sprintf("%s/api/v4/projects/%s/issues", t.baseURL(), url.PathEscape(t.cfg.Project)), body)
+}
+
+func (t *gitlabTracker) updateIssue(iid int, f types.Finding) error {
+	body := map[string]any{"description": issueBody(f)}
+	return t.send(http.MethodPut, fmt.Sprintf("%s/api/v4/projects/%s/issues/%d", t.baseURL(), url.PathEscape(t.cfg.Project), iid), body)
+}
+
+func (t *gitlabTracker) authenticate(req *http.Request) {
+	req.Header.Set("PRIVATE-TOKEN", t.cfg.Token)
+}
+
+func (t *gitlabTracker) send(method, url string, body map[string]any) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(method, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	t.authenticate(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call GitLab API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("GitLab API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
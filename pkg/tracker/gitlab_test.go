@@ -0,0 +1,74 @@
+package tracker
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestGitLabTracker_Sync_createsIssueWhenNoneExists(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.EscapedPath())
+		switch {
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == "/api/v4/projects/group%2Fproject/issues":
+			fmt.Fprint(w, `[]`)
+		case r.Method == http.MethodPost && r.URL.EscapedPath() == "/api/v4/projects/group%2Fproject/issues":
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.EscapedPath())
+		}
+	}))
+	defer server.Close()
+
+	tr := newGitLabTracker(&Config{BaseURL: server.URL, Token: "tok", Project: "group/project", Label: "trivy"})
+
+	err := tr.Sync("alpine:3.10", types.Finding{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl", PkgVersion: "1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GET /api/v4/projects/group%2Fproject/issues", "POST /api/v4/projects/group%2Fproject/issues"}, calls)
+}
+
+func TestGitLabTracker_Sync_updatesExistingIssueInsteadOfCreating(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.EscapedPath())
+		switch {
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == "/api/v4/projects/group%2Fproject/issues":
+			fmt.Fprint(w, `[{"iid": 7}]`)
+		case r.Method == http.MethodPut && r.URL.EscapedPath() == "/api/v4/projects/group%2Fproject/issues/7":
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.EscapedPath())
+		}
+	}))
+	defer server.Close()
+
+	tr := newGitLabTracker(&Config{BaseURL: server.URL, Token: "tok", Project: "group/project", Label: "trivy"})
+
+	err := tr.Sync("alpine:3.10", types.Finding{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl", PkgVersion: "1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GET /api/v4/projects/group%2Fproject/issues", "PUT /api/v4/projects/group%2Fproject/issues/7"}, calls)
+}
+
+func TestGitLabTracker_Sync_nonSuccessStatusIsAnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	tr := newGitLabTracker(&Config{BaseURL: server.URL, Token: "tok", Project: "group/project", Label: "trivy"})
+
+	err := tr.Sync("alpine:3.10", types.Finding{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+}
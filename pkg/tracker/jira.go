@@ -0,0 +1,119 @@
+package tracker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+type jiraTracker struct {
+	cfg    *Config
+	client *http.Client
+}
+
+func newJiraTracker(cfg *Config) *jiraTracker {
+	return &jiraTracker{cfg: cfg, client: newHTTPClient()}
+}
+
+// Sync opens or updates a Jira issue for the finding. Jira has no concept of
+// issue labels on search by default, so the fingerprint is embedded in the
+// description and matched via JQL.
+func (t *jiraTracker) Sync(target string, f types.Finding) error {
+	fingerprint := Fingerprint(target, f)
+
+	existing, err := t.findIssue(fingerprint)
+	if err != nil {
+		return err
+	}
+	if existing != "" {
+		return t.updateIssue(existing, fingerprint, f)
+	}
+	return t.createIssue(fingerprint, f)
+}
+
+func (t *jiraTracker) findIssue(fingerprint string) (string, error) {
+	jql := fmt.Sprintf(`project = "%s" AND description ~ "%s"`, t.cfg.Project, fingerprint)
+	body := map[string]any{"jql": jql, "maxResults": 1}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, t.cfg.BaseURL+"/rest/api/2/search", bytes.NewReader(b))
+	if err != nil {
+		return "", err
+	}
+	t.authenticate(req)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to search Jira issues: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Issues []struct {
+			Key string `json:"key"`
+		} `json:"issues"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode Jira search response: %w", err)
+	}
+	if len(result.Issues) == 0 {
+		return "", nil
+	}
+	return result.Issues[0].Key, nil
+}
+
+func (t *jiraTracker) createIssue(fingerprint string, f types.Finding) error {
+	body := map[string]any{
+		"fields": map[string]any{
+			"project":     map[string]string{"key": t.cfg.Project},
+			"summary":     issueTitle(f),
+			"description": issueBody(f) + "\n\nfingerprint: " + fingerprint,
+			"issuetype":   map[string]string{"name": "Bug"},
+			"labels":      []string{t.cfg.Label},
+		},
+	}
+	return t.send(http.MethodPost, t.cfg.BaseURL+"/rest/api/2/issue", body)
+}
+
+func (t *jiraTracker) updateIssue(key, fingerprint string, f types.Finding) error {
+	body := map[string]any{
+		"fields": map[string]any{
+			"description": issueBody(f) + "\n\nfingerprint: " + fingerprint,
+		},
+	}
+	return t.send(http.MethodPut, fmt.Sprintf("%s/rest/api/2/issue/%s", t.cfg.BaseURL, key), body)
+}
+
+func (t *jiraTracker) authenticate(req *http.Request) {
+	req.Header.Set("Authorization", "Bearer "+t.cfg.Token)
+}
+
+func (t *jiraTracker) send(method, url string, body map[string]any) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(method, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	t.authenticate(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call Jira API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Jira API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package tracker
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestJiraTracker_Sync_createsIssueWhenNoneExists(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/search":
+			fmt.Fprint(w, `{"issues": []}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue":
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	tr := newJiraTracker(&Config{BaseURL: server.URL, Token: "tok", Project: "SEC", Label: "trivy"})
+
+	err := tr.Sync("alpine:3.10", types.Finding{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl", PkgVersion: "1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"POST /rest/api/2/search", "POST /rest/api/2/issue"}, calls)
+}
+
+func TestJiraTracker_Sync_updatesExistingIssueInsteadOfCreating(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/search":
+			fmt.Fprint(w, `{"issues": [{"key": "SEC-42"}]}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/rest/api/2/issue/SEC-42":
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	tr := newJiraTracker(&Config{BaseURL: server.URL, Token: "tok", Project: "SEC", Label: "trivy"})
+
+	err := tr.Sync("alpine:3.10", types.Finding{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl", PkgVersion: "1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"POST /rest/api/2/search", "PUT /rest/api/2/issue/SEC-42"}, calls)
+}
+
+func TestJiraTracker_Sync_nonSuccessStatusIsAnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/api/2/search" {
+			fmt.Fprint(w, `{"issues": []}`)
+			return
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	tr := newJiraTracker(&Config{BaseURL: server.URL, Token: "tok", Project: "SEC", Label: "trivy"})
+
+	err := tr.Sync("alpine:3.10", types.Finding{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "502")
+}
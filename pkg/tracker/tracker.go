@@ -0,0 +1,128 @@
+// Package tracker lets a scan open or update issues in an external tracker
+// (GitHub, GitLab, or Jira) instead of writing a report file, so a team's
+// existing triage workflow can pick up new findings automatically.
+package tracker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// Providers lists the tracker backends Config.Provider may name.
+var Providers = []string{"github", "gitlab", "jira"}
+
+// httpTimeout bounds every tracker API call so a slow or hanging provider
+// can't block a scan indefinitely; Sync is called once per finding in a
+// tight loop, so there is no other backstop.
+const httpTimeout = 30 * time.Second
+
+// newHTTPClient returns the *http.Client every adapter should use, instead
+// of http.DefaultClient, which has no timeout.
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: httpTimeout}
+}
+
+// Config is loaded from the YAML file passed via --tracker-config.
+type Config struct {
+	Provider string `yaml:"provider"`
+	BaseURL  string `yaml:"base-url"`
+	Token    string `yaml:"token"`
+	// Project is the GitHub "owner/repo", the GitLab project path, or the
+	// Jira project key to file issues against.
+	Project string `yaml:"project"`
+	// Label tags issues opened by trivy so re-runs can find and update them
+	// instead of filing duplicates.
+	Label string `yaml:"label"`
+}
+
+// LoadConfig reads and validates a tracker config file.
+func LoadConfig(filePath string) (*Config, error) {
+	b, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tracker config: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(b, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse tracker config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Validate checks that the config is usable before a scan starts, rather
+// than failing midway through filing issues.
+func (c *Config) Validate() error {
+	if !contains(Providers, c.Provider) {
+		return fmt.Errorf("unknown tracker provider %q, must be one of %v", c.Provider, Providers)
+	}
+	if c.Token == "" {
+		return fmt.Errorf("tracker provider %q requires a 'token'", c.Provider)
+	}
+	if c.Project == "" {
+		return fmt.Errorf("tracker provider %q requires a 'project'", c.Provider)
+	}
+	if c.Label == "" {
+		c.Label = "trivy"
+	}
+	return nil
+}
+
+// Tracker opens or updates a tracker issue for a single finding. Adapters
+// are expected to be idempotent: calling Sync twice for the same finding on
+// the same target must update the existing issue, not create a second one.
+type Tracker interface {
+	// Sync opens a new issue for the finding, or updates the existing one
+	// carrying its fingerprint.
+	Sync(target string, f types.Finding) error
+}
+
+// New returns the Tracker adapter for cfg.Provider.
+func New(cfg *Config) (Tracker, error) {
+	switch cfg.Provider {
+	case "github":
+		return newGitHubTracker(cfg), nil
+	case "gitlab":
+		return newGitLabTracker(cfg), nil
+	case "jira":
+		return newJiraTracker(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown tracker provider %q", cfg.Provider)
+	}
+}
+
+// Fingerprint is a stable identifier for a finding on a given scan target,
+// attached to the issue so re-runs update rather than duplicate: as a label
+// on GitHub and GitLab, and in the issue description on Jira.
+func Fingerprint(target string, f types.Finding) string {
+	sum := sha256.Sum256([]byte(f.VulnerabilityID + "|" + f.PkgName + "|" + f.PkgVersion + "|" + target))
+	return "trivy-" + hex.EncodeToString(sum[:])[:16]
+}
+
+func issueTitle(f types.Finding) string {
+	return fmt.Sprintf("%s in %s %s", f.VulnerabilityID, f.PkgName, f.PkgVersion)
+}
+
+func issueBody(f types.Finding) string {
+	return fmt.Sprintf("Package: %s %s\nType: %s\nSeverity: %s\nFixed version: %s\nLocation: %s",
+		f.PkgName, f.PkgVersion, f.PkgType, f.Severity, f.FixedVersion, f.FilePath)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package tracker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{
+			name: "valid github config",
+			cfg:  Config{Provider: "github", Token: "tok", Project: "owner/repo"},
+		},
+		{
+			name:    "unknown provider",
+			cfg:     Config{Provider: "bitbucket", Token: "tok", Project: "owner/repo"},
+			wantErr: "unknown tracker provider",
+		},
+		{
+			name:    "missing token",
+			cfg:     Config{Provider: "gitlab", Project: "group/project"},
+			wantErr: "requires a 'token'",
+		},
+		{
+			name:    "missing project",
+			cfg:     Config{Provider: "jira", Token: "tok"},
+			wantErr: "requires a 'project'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "trivy", tt.cfg.Label, "default label should be applied")
+		})
+	}
+}
+
+func TestFingerprint_stableAndDistinct(t *testing.T) {
+	f1 := types.Finding{VulnerabilityID: "CVE-2021-1111", PkgName: "openssl", PkgVersion: "1.0.0"}
+	f2 := types.Finding{VulnerabilityID: "CVE-2021-2222", PkgName: "openssl", PkgVersion: "1.0.0"}
+
+	assert.Equal(t, Fingerprint("alpine:3.10", f1), Fingerprint("alpine:3.10", f1), "fingerprint must be stable across calls")
+	assert.NotEqual(t, Fingerprint("alpine:3.10", f1), Fingerprint("alpine:3.10", f2), "different vulnerabilities must not collide")
+	assert.NotEqual(t, Fingerprint("alpine:3.10", f1), Fingerprint("alpine:3.11", f1), "different targets must not collide")
+}
@@ -0,0 +1,49 @@
+package types
+
+// VulnType represents vulnerability type
+const (
+	VulnTypeOS      = "os"
+	VulnTypeLibrary = "library"
+)
+
+// VulnTypes holds the known vulnerability types
+var VulnTypes = []string{VulnTypeOS, VulnTypeLibrary}
+
+// SecurityCheck represents the kind of security check to perform
+const (
+	SecurityCheckVulnerability = "vuln"
+	SecurityCheckConfig        = "config"
+	SecurityCheckSecret        = "secret"
+)
+
+// SecurityChecks holds the known security check kinds
+var SecurityChecks = []string{SecurityCheckVulnerability, SecurityCheckConfig, SecurityCheckSecret}
+
+// Finding is the subset of a detected vulnerability that ignore rules match
+// against. It is deliberately flat so that rule predicates stay simple.
+type Finding struct {
+	VulnerabilityID string // e.g. CVE-2021-12345
+	PkgName         string
+	PkgVersion      string // installed version of the affected package
+	PkgType         string // os, library, or a specific ecosystem such as npm/gem/pypi
+	FixedVersion    string
+	FixState        string // fixed, not-fixed, unknown, or wont-fix
+	Severity        string
+	FilePath        string // in-image file location of the affected package
+}
+
+// IgnoredMatch is a Finding that was suppressed by a .trivyignore entry, an
+// ignore-policy rule, or --ignore-unfixed, kept around so report formats can
+// surface it instead of dropping it silently.
+type IgnoredMatch struct {
+	Finding
+	Reason string
+}
+
+// Result holds the findings for a single scan target (an image layer, a
+// lockfile, etc.) after ignore rules have been applied.
+type Result struct {
+	Target          string
+	Vulnerabilities []Finding
+	IgnoredMatches  []IgnoredMatch
+}